@@ -26,10 +26,11 @@ func ResourceBucketAcl() *schema.Resource {
 		CreateContext: resourceBucketAclCreate,
 		ReadContext:   resourceBucketAclRead,
 		UpdateContext: resourceBucketAclUpdate,
-		DeleteContext: schema.NoopContext,
+		DeleteContext: resourceBucketAclDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceBucketAclCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"access_control_policy": {
@@ -37,7 +38,7 @@ func ResourceBucketAcl() *schema.Resource {
 				Optional:      true,
 				Computed:      true,
 				MaxItems:      1,
-				ConflictsWith: []string{"acl"},
+				ConflictsWith: []string{"acl", "policy_document"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"grant": {
@@ -107,9 +108,17 @@ func ResourceBucketAcl() *schema.Resource {
 			"acl": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"access_control_policy"},
+				ConflictsWith: []string{"access_control_policy", "policy_document"},
 				ValidateFunc:  validation.StringInSlice(BucketCannedACL_Values(), false),
 			},
+			"policy_document": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"acl", "access_control_policy"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: verify.SuppressEquivalentPolicyDiffs,
+			},
 			"bucket": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -122,6 +131,11 @@ func ResourceBucketAcl() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: verify.ValidAccountID,
 			},
+			"revert_to_private_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 		},
 	}
 }
@@ -149,6 +163,14 @@ func resourceBucketAclCreate(ctx context.Context, d *schema.ResourceData, meta i
 		input.AccessControlPolicy = expandBucketAclAccessControlPolicy(v.([]interface{}))
 	}
 
+	if v, ok := d.GetOk("policy_document"); ok {
+		policy, err := expandBucketAclPolicyDocument(ctx, conn, bucket, expectedBucketOwner, v.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error translating policy_document to S3 bucket ACL for %s: %w", bucket, err))
+		}
+		input.AccessControlPolicy = policy
+	}
+
 	_, err := verify.RetryOnAWSCode(s3.ErrCodeNoSuchBucket, func() (interface{}, error) {
 		return conn.PutBucketAclWithContext(ctx, input)
 	})
@@ -201,6 +223,12 @@ func resourceBucketAclRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(fmt.Errorf("error setting access_control_policy: %w", err))
 	}
 
+	policyDocument, err := flattenBucketAclPolicyDocument(bucket, output)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error flattening S3 bucket ACL (%s) to policy_document: %w", d.Id(), err))
+	}
+	d.Set("policy_document", policyDocument)
+
 	return nil
 }
 
@@ -224,6 +252,14 @@ func resourceBucketAclUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		input.AccessControlPolicy = expandBucketAclAccessControlPolicy(d.Get("access_control_policy").([]interface{}))
 	}
 
+	if d.HasChange("policy_document") {
+		policy, err := expandBucketAclPolicyDocument(ctx, conn, bucket, expectedBucketOwner, d.Get("policy_document").(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error translating policy_document to S3 bucket ACL (%s): %w", d.Id(), err))
+		}
+		input.AccessControlPolicy = policy
+	}
+
 	if d.HasChange("acl") {
 		acl = d.Get("acl").(string)
 		input.ACL = aws.String(acl)
@@ -243,6 +279,42 @@ func resourceBucketAclUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	return resourceBucketAclRead(ctx, d, meta)
 }
 
+func resourceBucketAclDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("revert_to_private_on_destroy").(bool) {
+		log.Printf("[DEBUG] Skipping reverting S3 Bucket ACL (%s), revert_to_private_on_destroy is false", d.Id())
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket, expectedBucketOwner, _, err := BucketACLParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &s3.PutBucketAclInput{
+		Bucket: aws.String(bucket),
+		ACL:    aws.String(s3.BucketCannedACLPrivate),
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	log.Printf("[DEBUG] Reverting S3 Bucket ACL (%s) to private on destroy", d.Id())
+	_, err = conn.PutBucketAclWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reverting S3 bucket ACL (%s) to private on destroy: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
 func expandBucketAclAccessControlPolicy(l []interface{}) *s3.AccessControlPolicy {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -0,0 +1,478 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Group grantee URIs S3 supports. All three are accepted for Group grantees
+// in access_control_policy; only AllUsers has a bucket-policy Principal
+// equivalent ("*") when translating to and from policy_document.
+const (
+	bucketAclGroupURIAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	bucketAclGroupURIAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	bucketAclGroupURILogDelivery        = "http://acs.amazonaws.com/groups/s3/LogDelivery"
+)
+
+// bucketAclPolicyActionPermissions folds IAM-style S3 actions into the
+// canned ACL permission they imply. Actions not listed here cannot be
+// expressed as a grant and are rejected.
+var bucketAclPolicyActionPermissions = map[string]string{
+	"s3:GetObject":    s3.PermissionRead,
+	"s3:ListBucket":   s3.PermissionRead,
+	"s3:PutObject":    s3.PermissionWrite,
+	"s3:DeleteObject": s3.PermissionWrite,
+	"s3:GetBucketAcl": s3.PermissionReadAcp,
+	"s3:PutBucketAcl": s3.PermissionWriteAcp,
+}
+
+// bucketAclPolicyPermissionActions is the inverse of bucketAclPolicyActionPermissions,
+// used to flatten a grant's permission back into the canonical action list
+// for the same permission.
+var bucketAclPolicyPermissionActions = map[string][]string{
+	s3.PermissionRead:     {"s3:GetObject", "s3:ListBucket"},
+	s3.PermissionWrite:    {"s3:PutObject", "s3:DeleteObject"},
+	s3.PermissionReadAcp:  {"s3:GetBucketAcl"},
+	s3.PermissionWriteAcp: {"s3:PutBucketAcl"},
+}
+
+var bucketAclPolicyFullControlPermissions = []string{
+	s3.PermissionRead,
+	s3.PermissionWrite,
+	s3.PermissionReadAcp,
+	s3.PermissionWriteAcp,
+}
+
+type bucketAclPolicyDocument struct {
+	Version   string                     `json:"Version,omitempty"`
+	Statement []bucketAclPolicyStatement `json:"Statement"`
+}
+
+type bucketAclPolicyStatement struct {
+	Sid       string                   `json:"Sid,omitempty"`
+	Effect    string                   `json:"Effect"`
+	Principal bucketAclPolicyPrincipal `json:"Principal"`
+	Action    bucketAclPolicyStringSet `json:"Action"`
+	Resource  bucketAclPolicyStringSet `json:"Resource"`
+	Condition json.RawMessage          `json:"Condition,omitempty"`
+}
+
+type bucketAclPolicyPrincipal struct {
+	AWS bucketAclPolicyStringSet `json:"AWS"`
+}
+
+// bucketAclPolicyStringSet accepts either a bare JSON string or a JSON
+// array of strings, matching the shape IAM policy documents use for
+// Action, Resource, and Principal.AWS.
+type bucketAclPolicyStringSet []string
+
+func (s *bucketAclPolicyStringSet) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*s = bucketAclPolicyStringSet{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*s = bucketAclPolicyStringSet(list)
+	return nil
+}
+
+func (s bucketAclPolicyStringSet) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+// expandBucketAclPolicyDocument translates an IAM-style bucket policy
+// document into an AccessControlPolicy that can be pushed via PutBucketAcl.
+// The owner is always fetched from the live bucket rather than the policy
+// document, since ACLs have no equivalent of Principal for the owner.
+func expandBucketAclPolicyDocument(ctx context.Context, conn *s3.S3, bucket, expectedBucketOwner, policyJSON string) (*s3.AccessControlPolicy, error) {
+	doc, err := parseBucketAclPolicyDocument(policyJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBucketAclPolicyDocument(doc, bucket); err != nil {
+		return nil, err
+	}
+
+	getInput := &s3.GetBucketAclInput{
+		Bucket: aws.String(bucket),
+	}
+	if expectedBucketOwner != "" {
+		getInput.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	current, err := conn.GetBucketAclWithContext(ctx, getInput)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current S3 bucket ACL for %s: %w", bucket, err)
+	}
+
+	grants, err := expandBucketAclPolicyDocumentGrants(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.AccessControlPolicy{
+		Grants: grants,
+		Owner:  current.Owner,
+	}, nil
+}
+
+func parseBucketAclPolicyDocument(policyJSON string) (*bucketAclPolicyDocument, error) {
+	var doc bucketAclPolicyDocument
+	if err := json.Unmarshal([]byte(policyJSON), &doc); err != nil {
+		return nil, fmt.Errorf("error parsing policy_document: %w", err)
+	}
+	return &doc, nil
+}
+
+// validateBucketAclPolicyDocument rejects statement shapes that an ACL
+// cannot express: anything other than Allow, Condition blocks, and
+// resources other than the bucket itself or "bucket/*".
+func validateBucketAclPolicyDocument(doc *bucketAclPolicyDocument, bucket string) error {
+	bucketArn := fmt.Sprintf("arn:aws:s3:::%s", bucket)
+	objectsArn := fmt.Sprintf("arn:aws:s3:::%s/*", bucket)
+
+	for _, stmt := range doc.Statement {
+		if stmt.Effect != "Allow" {
+			return fmt.Errorf("policy_document statement %q: only Effect=Allow can be translated to an ACL grant", stmt.Sid)
+		}
+
+		if len(stmt.Condition) > 0 {
+			return fmt.Errorf("policy_document statement %q: Condition blocks cannot be expressed in a bucket ACL", stmt.Sid)
+		}
+
+		for _, resource := range stmt.Resource {
+			if resource != bucketArn && resource != objectsArn {
+				return fmt.Errorf("policy_document statement %q: Resource %q must be %q or %q, bucket ACLs cannot express other resource patterns", stmt.Sid, resource, bucketArn, objectsArn)
+			}
+		}
+
+		if _, err := expandBucketAclPolicyStatementGrantees(stmt); err != nil {
+			return fmt.Errorf("policy_document statement %q: %w", stmt.Sid, err)
+		}
+
+		if _, err := expandBucketAclPolicyStatementPermissions(stmt); err != nil {
+			return fmt.Errorf("policy_document statement %q: %w", stmt.Sid, err)
+		}
+	}
+
+	return nil
+}
+
+func expandBucketAclPolicyStatementGrantees(stmt bucketAclPolicyStatement) ([]*s3.Grantee, error) {
+	var grantees []*s3.Grantee
+
+	for _, principal := range stmt.Principal.AWS {
+		if principal == "*" {
+			grantees = append(grantees, &s3.Grantee{
+				Type: aws.String(s3.TypeGroup),
+				URI:  aws.String(bucketAclGroupURIAllUsers),
+			})
+			continue
+		}
+
+		grantees = append(grantees, &s3.Grantee{
+			Type: aws.String(s3.TypeCanonicalUser),
+			ID:   aws.String(principal),
+		})
+	}
+
+	if len(grantees) == 0 {
+		return nil, fmt.Errorf("Principal.AWS must be set to \"*\" or a canonical user ID")
+	}
+
+	return grantees, nil
+}
+
+func expandBucketAclPolicyStatementPermissions(stmt bucketAclPolicyStatement) ([]string, error) {
+	permissionSet := make(map[string]struct{})
+
+	for _, action := range stmt.Action {
+		permission, ok := bucketAclPolicyActionPermissions[action]
+		if !ok {
+			return nil, fmt.Errorf("Action %q cannot be translated to an ACL permission", action)
+		}
+		permissionSet[permission] = struct{}{}
+	}
+
+	if isBucketAclPolicyFullControl(permissionSet) {
+		return []string{s3.PermissionFullControl}, nil
+	}
+
+	permissions := make([]string, 0, len(permissionSet))
+	for permission := range permissionSet {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return permissions, nil
+}
+
+func isBucketAclPolicyFullControl(permissionSet map[string]struct{}) bool {
+	for _, permission := range bucketAclPolicyFullControlPermissions {
+		if _, ok := permissionSet[permission]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func expandBucketAclPolicyDocumentGrants(doc *bucketAclPolicyDocument) ([]*s3.Grant, error) {
+	var grants []*s3.Grant
+
+	for _, stmt := range doc.Statement {
+		grantees, err := expandBucketAclPolicyStatementGrantees(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("policy_document statement %q: %w", stmt.Sid, err)
+		}
+
+		permissions, err := expandBucketAclPolicyStatementPermissions(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("policy_document statement %q: %w", stmt.Sid, err)
+		}
+
+		for _, grantee := range grantees {
+			for _, permission := range permissions {
+				grants = append(grants, &s3.Grant{
+					Grantee:    grantee,
+					Permission: aws.String(permission),
+				})
+			}
+		}
+	}
+
+	return grants, nil
+}
+
+// flattenBucketAclPolicyDocument is the inverse of expandBucketAclPolicyDocument,
+// folding the current ACL grants back into a canonical-form policy document
+// so that drift against a configured policy_document can be detected.
+func flattenBucketAclPolicyDocument(bucket string, output *s3.GetBucketAclOutput) (string, error) {
+	if output == nil || len(output.Grants) == 0 {
+		return "", nil
+	}
+
+	type granteeKey struct {
+		typ string
+		id  string
+		uri string
+	}
+
+	permissionsByGrantee := make(map[granteeKey]map[string]struct{})
+	var order []granteeKey
+
+	for _, grant := range output.Grants {
+		if grant == nil || grant.Grantee == nil || grant.Permission == nil {
+			continue
+		}
+
+		key := granteeKey{
+			typ: aws.StringValue(grant.Grantee.Type),
+			id:  aws.StringValue(grant.Grantee.ID),
+			uri: aws.StringValue(grant.Grantee.URI),
+		}
+
+		if key.typ == s3.TypeGroup && key.uri != bucketAclGroupURIAllUsers {
+			// Not representable as a bucket-policy principal; skip it.
+			continue
+		}
+		if key.typ != s3.TypeGroup && key.typ != s3.TypeCanonicalUser {
+			continue
+		}
+
+		if _, ok := permissionsByGrantee[key]; !ok {
+			permissionsByGrantee[key] = make(map[string]struct{})
+			order = append(order, key)
+		}
+		permissionsByGrantee[key][aws.StringValue(grant.Permission)] = struct{}{}
+	}
+
+	var statements []bucketAclPolicyStatement
+
+	for _, key := range order {
+		permissionSet := permissionsByGrantee[key]
+
+		actionSet := make(map[string]struct{})
+		if _, ok := permissionSet[s3.PermissionFullControl]; ok {
+			for _, permission := range bucketAclPolicyFullControlPermissions {
+				for _, action := range bucketAclPolicyPermissionActions[permission] {
+					actionSet[action] = struct{}{}
+				}
+			}
+		} else {
+			for permission := range permissionSet {
+				for _, action := range bucketAclPolicyPermissionActions[permission] {
+					actionSet[action] = struct{}{}
+				}
+			}
+		}
+
+		if len(actionSet) == 0 {
+			continue
+		}
+
+		actions := make([]string, 0, len(actionSet))
+		for action := range actionSet {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+
+		principal := key.id
+		if key.typ == s3.TypeGroup {
+			principal = "*"
+		}
+
+		statements = append(statements, bucketAclPolicyStatement{
+			Effect:    "Allow",
+			Principal: bucketAclPolicyPrincipal{AWS: bucketAclPolicyStringSet{principal}},
+			Action:    bucketAclPolicyStringSet(actions),
+			Resource: bucketAclPolicyStringSet{
+				fmt.Sprintf("arn:aws:s3:::%s", bucket),
+				fmt.Sprintf("arn:aws:s3:::%s/*", bucket),
+			},
+		})
+	}
+
+	if len(statements) == 0 {
+		return "", nil
+	}
+
+	doc := bucketAclPolicyDocument{
+		Version:   "2012-10-17",
+		Statement: statements,
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("error flattening ACL grants to policy_document: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// resourceBucketAclCustomizeDiff validates a configured policy_document and
+// the grantee/permission combinations in access_control_policy against the
+// ACL translation rules at plan time, before any API call is made.
+func resourceBucketAclCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if v, ok := d.GetOk("policy_document"); ok {
+		doc, err := parseBucketAclPolicyDocument(v.(string))
+		if err != nil {
+			return err
+		}
+
+		if err := validateBucketAclPolicyDocument(doc, d.Get("bucket").(string)); err != nil {
+			return err
+		}
+	}
+
+	return validateBucketAclAccessControlPolicyGrants(d)
+}
+
+// validateBucketAclAccessControlPolicyGrants enforces that each grant's
+// grantee only sets the fields valid for its type, and that group grantees
+// are restricted to the closed set of AWS group URIs and the permissions
+// each one supports.
+func validateBucketAclAccessControlPolicyGrants(d *schema.ResourceDiff) error {
+	v, ok := d.GetOk("access_control_policy")
+	if !ok {
+		return nil
+	}
+
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	tfMap, ok := l[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	grantSet, ok := tfMap["grant"].(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	for _, grantRaw := range grantSet.List() {
+		grantMap, ok := grantRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		permission, _ := grantMap["permission"].(string)
+
+		granteeList, ok := grantMap["grantee"].([]interface{})
+		if !ok || len(granteeList) == 0 || granteeList[0] == nil {
+			continue
+		}
+
+		granteeMap, ok := granteeList[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if err := validateBucketAclGrantee(granteeMap, permission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateBucketAclGrantee(tfMap map[string]interface{}, permission string) error {
+	granteeType, _ := tfMap["type"].(string)
+	id, _ := tfMap["id"].(string)
+	uri, _ := tfMap["uri"].(string)
+	email, _ := tfMap["email_address"].(string)
+
+	switch granteeType {
+	case s3.TypeCanonicalUser:
+		if id == "" {
+			return fmt.Errorf(`grantee type %q requires "id" to be set`, granteeType)
+		}
+		if uri != "" || email != "" {
+			return fmt.Errorf(`grantee type %q does not support "uri" or "email_address"`, granteeType)
+		}
+	case s3.TypeGroup:
+		if uri == "" {
+			return fmt.Errorf(`grantee type %q requires "uri" to be set`, granteeType)
+		}
+		if id != "" || email != "" {
+			return fmt.Errorf(`grantee type %q does not support "id" or "email_address"`, granteeType)
+		}
+
+		switch uri {
+		case bucketAclGroupURIAllUsers, bucketAclGroupURIAuthenticatedUsers, bucketAclGroupURILogDelivery:
+			// Valid AWS group URI.
+		default:
+			return fmt.Errorf(`grantee type %q "uri" must be one of %q, %q, or %q, got %q`, granteeType, bucketAclGroupURIAllUsers, bucketAclGroupURIAuthenticatedUsers, bucketAclGroupURILogDelivery, uri)
+		}
+
+		if uri == bucketAclGroupURILogDelivery && permission != s3.PermissionWrite && permission != s3.PermissionReadAcp {
+			return fmt.Errorf(`grantee uri %q only supports %q or %q permissions, got %q`, bucketAclGroupURILogDelivery, s3.PermissionWrite, s3.PermissionReadAcp, permission)
+		}
+	case s3.TypeAmazonCustomerByEmail:
+		if email == "" {
+			return fmt.Errorf(`grantee type %q requires "email_address" to be set`, granteeType)
+		}
+		if id != "" || uri != "" {
+			return fmt.Errorf(`grantee type %q does not support "id" or "uri"`, granteeType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestExpandBucketAclPolicyDocumentGrants(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policyJSON string
+		wantGrants []*s3.Grant
+	}{
+		{
+			name: "allow AllUsers read",
+			policyJSON: `{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "*"},
+					"Action": "s3:GetObject",
+					"Resource": "arn:aws:s3:::mybucket/*"
+				}]
+			}`,
+			wantGrants: []*s3.Grant{
+				{
+					Grantee: &s3.Grantee{
+						Type: aws.String(s3.TypeGroup),
+						URI:  aws.String(bucketAclGroupURIAllUsers),
+					},
+					Permission: aws.String(s3.PermissionRead),
+				},
+			},
+		},
+		{
+			name: "full control folds to a single grant",
+			policyJSON: `{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "canonical-owner-id"},
+					"Action": ["s3:GetObject", "s3:ListBucket", "s3:PutObject", "s3:DeleteObject", "s3:GetBucketAcl", "s3:PutBucketAcl"],
+					"Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]
+				}]
+			}`,
+			wantGrants: []*s3.Grant{
+				{
+					Grantee: &s3.Grantee{
+						Type: aws.String(s3.TypeCanonicalUser),
+						ID:   aws.String("canonical-owner-id"),
+					},
+					Permission: aws.String(s3.PermissionFullControl),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			doc, err := parseBucketAclPolicyDocument(testCase.policyJSON)
+			if err != nil {
+				t.Fatalf("unexpected error parsing policy_document: %s", err)
+			}
+
+			if err := validateBucketAclPolicyDocument(doc, "mybucket"); err != nil {
+				t.Fatalf("unexpected error validating policy_document: %s", err)
+			}
+
+			got, err := expandBucketAclPolicyDocumentGrants(doc)
+			if err != nil {
+				t.Fatalf("unexpected error expanding policy_document: %s", err)
+			}
+
+			if len(got) != len(testCase.wantGrants) {
+				t.Fatalf("got %d grants, want %d", len(got), len(testCase.wantGrants))
+			}
+
+			for i, wantGrant := range testCase.wantGrants {
+				gotGrant := got[i]
+				if aws.StringValue(gotGrant.Permission) != aws.StringValue(wantGrant.Permission) {
+					t.Errorf("grant %d: got permission %q, want %q", i, aws.StringValue(gotGrant.Permission), aws.StringValue(wantGrant.Permission))
+				}
+				if aws.StringValue(gotGrant.Grantee.Type) != aws.StringValue(wantGrant.Grantee.Type) {
+					t.Errorf("grant %d: got grantee type %q, want %q", i, aws.StringValue(gotGrant.Grantee.Type), aws.StringValue(wantGrant.Grantee.Type))
+				}
+				if aws.StringValue(gotGrant.Grantee.URI) != aws.StringValue(wantGrant.Grantee.URI) {
+					t.Errorf("grant %d: got grantee URI %q, want %q", i, aws.StringValue(gotGrant.Grantee.URI), aws.StringValue(wantGrant.Grantee.URI))
+				}
+				if aws.StringValue(gotGrant.Grantee.ID) != aws.StringValue(wantGrant.Grantee.ID) {
+					t.Errorf("grant %d: got grantee ID %q, want %q", i, aws.StringValue(gotGrant.Grantee.ID), aws.StringValue(wantGrant.Grantee.ID))
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenBucketAclPolicyDocument_FullControlFolding(t *testing.T) {
+	output := &s3.GetBucketAclOutput{
+		Grants: []*s3.Grant{
+			{
+				Grantee: &s3.Grantee{
+					Type: aws.String(s3.TypeCanonicalUser),
+					ID:   aws.String("canonical-owner-id"),
+				},
+				Permission: aws.String(s3.PermissionFullControl),
+			},
+		},
+	}
+
+	policyJSON, err := flattenBucketAclPolicyDocument("mybucket", output)
+	if err != nil {
+		t.Fatalf("unexpected error flattening ACL: %s", err)
+	}
+
+	doc, err := parseBucketAclPolicyDocument(policyJSON)
+	if err != nil {
+		t.Fatalf("unexpected error parsing flattened policy_document: %s", err)
+	}
+
+	if len(doc.Statement) != 1 {
+		t.Fatalf("got %d statements, want 1", len(doc.Statement))
+	}
+
+	wantActions := map[string]struct{}{
+		"s3:GetObject":    {},
+		"s3:ListBucket":   {},
+		"s3:PutObject":    {},
+		"s3:DeleteObject": {},
+		"s3:GetBucketAcl": {},
+		"s3:PutBucketAcl": {},
+	}
+
+	stmt := doc.Statement[0]
+	if len(stmt.Action) != len(wantActions) {
+		t.Fatalf("got %d actions, want %d", len(stmt.Action), len(wantActions))
+	}
+	for _, action := range stmt.Action {
+		if _, ok := wantActions[action]; !ok {
+			t.Errorf("unexpected action %q in flattened policy_document", action)
+		}
+	}
+}
+
+func TestValidateBucketAclPolicyDocument_Rejections(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policyJSON string
+	}{
+		{
+			name: "deny effect",
+			policyJSON: `{
+				"Statement": [{
+					"Effect": "Deny",
+					"Principal": {"AWS": "*"},
+					"Action": "s3:GetObject",
+					"Resource": "arn:aws:s3:::mybucket/*"
+				}]
+			}`,
+		},
+		{
+			name: "condition present",
+			policyJSON: `{
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "*"},
+					"Action": "s3:GetObject",
+					"Resource": "arn:aws:s3:::mybucket/*",
+					"Condition": {"Bool": {"aws:SecureTransport": "true"}}
+				}]
+			}`,
+		},
+		{
+			name: "bad resource",
+			policyJSON: `{
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "*"},
+					"Action": "s3:GetObject",
+					"Resource": "arn:aws:s3:::mybucket/prefix/*"
+				}]
+			}`,
+		},
+		{
+			name: "unsupported action",
+			policyJSON: `{
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": "*"},
+					"Action": "s3:GetBucketLocation",
+					"Resource": "arn:aws:s3:::mybucket"
+				}]
+			}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			doc, err := parseBucketAclPolicyDocument(testCase.policyJSON)
+			if err != nil {
+				t.Fatalf("unexpected error parsing policy_document: %s", err)
+			}
+
+			if err := validateBucketAclPolicyDocument(doc, "mybucket"); err == nil {
+				t.Error("expected validation error, got none")
+			}
+		})
+	}
+}
+
+func TestValidateBucketAclGrantee(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tfMap      map[string]interface{}
+		permission string
+		wantErr    bool
+	}{
+		{
+			name:       "canonical user with id",
+			tfMap:      map[string]interface{}{"type": s3.TypeCanonicalUser, "id": "canonical-owner-id"},
+			permission: s3.PermissionFullControl,
+		},
+		{
+			name:       "canonical user missing id",
+			tfMap:      map[string]interface{}{"type": s3.TypeCanonicalUser},
+			permission: s3.PermissionFullControl,
+			wantErr:    true,
+		},
+		{
+			name:       "canonical user with uri",
+			tfMap:      map[string]interface{}{"type": s3.TypeCanonicalUser, "id": "canonical-owner-id", "uri": bucketAclGroupURIAllUsers},
+			permission: s3.PermissionFullControl,
+			wantErr:    true,
+		},
+		{
+			name:       "canonical user with email_address",
+			tfMap:      map[string]interface{}{"type": s3.TypeCanonicalUser, "id": "canonical-owner-id", "email_address": "user@example.com"},
+			permission: s3.PermissionFullControl,
+			wantErr:    true,
+		},
+		{
+			name:       "group with AllUsers uri",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": bucketAclGroupURIAllUsers},
+			permission: s3.PermissionRead,
+		},
+		{
+			name:       "group missing uri",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+		{
+			name:       "group with id",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": bucketAclGroupURIAllUsers, "id": "canonical-owner-id"},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+		{
+			name:       "group with unsupported uri",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": "http://acs.amazonaws.com/groups/global/NotAGroup"},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+		{
+			name:       "group LogDelivery with WRITE permission",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": bucketAclGroupURILogDelivery},
+			permission: s3.PermissionWrite,
+		},
+		{
+			name:       "group LogDelivery with READ_ACP permission",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": bucketAclGroupURILogDelivery},
+			permission: s3.PermissionReadAcp,
+		},
+		{
+			name:       "group LogDelivery with unsupported permission",
+			tfMap:      map[string]interface{}{"type": s3.TypeGroup, "uri": bucketAclGroupURILogDelivery},
+			permission: s3.PermissionFullControl,
+			wantErr:    true,
+		},
+		{
+			name:       "email grantee with email_address",
+			tfMap:      map[string]interface{}{"type": s3.TypeAmazonCustomerByEmail, "email_address": "user@example.com"},
+			permission: s3.PermissionRead,
+		},
+		{
+			name:       "email grantee missing email_address",
+			tfMap:      map[string]interface{}{"type": s3.TypeAmazonCustomerByEmail},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+		{
+			name:       "email grantee with id",
+			tfMap:      map[string]interface{}{"type": s3.TypeAmazonCustomerByEmail, "email_address": "user@example.com", "id": "canonical-owner-id"},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+		{
+			name:       "email grantee with uri",
+			tfMap:      map[string]interface{}{"type": s3.TypeAmazonCustomerByEmail, "email_address": "user@example.com", "uri": bucketAclGroupURIAllUsers},
+			permission: s3.PermissionRead,
+			wantErr:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateBucketAclGrantee(testCase.tfMap, testCase.permission)
+			if testCase.wantErr && err == nil {
+				t.Error("expected validation error, got none")
+			}
+			if !testCase.wantErr && err != nil {
+				t.Errorf("unexpected validation error: %s", err)
+			}
+		})
+	}
+}
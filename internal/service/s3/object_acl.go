@@ -0,0 +1,405 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+const (
+	ObjectAclKeySeparator = "/"
+)
+
+func ResourceObjectAcl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObjectAclCreate,
+		ReadContext:   resourceObjectAclRead,
+		UpdateContext: resourceObjectAclUpdate,
+		DeleteContext: resourceObjectAclDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceObjectAclCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"access_control_policy": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"acl"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"grant": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"grantee": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"email_address": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"display_name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"id": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"type": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice(s3.Type_Values(), false),
+												},
+												"uri": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+									"permission": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(s3.Permission_Values(), false),
+									},
+								},
+							},
+						},
+						"owner": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"display_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"acl": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"access_control_policy"},
+				ValidateFunc:  validation.StringInSlice(ObjectCannedACL_Values(), false),
+			},
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"expected_bucket_owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: verify.ValidAccountID,
+			},
+			"revert_to_private_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceObjectAclCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+	expectedBucketOwner := d.Get("expected_bucket_owner").(string)
+	acl := d.Get("acl").(string)
+
+	input := &s3.PutObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	if acl != "" {
+		input.ACL = aws.String(acl)
+	}
+
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	if v, ok := d.GetOk("access_control_policy"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		input.AccessControlPolicy = expandBucketAclAccessControlPolicy(v.([]interface{}))
+	}
+
+	_, err := verify.RetryOnAWSCode(s3.ErrCodeNoSuchBucket, func() (interface{}, error) {
+		return conn.PutObjectAclWithContext(ctx, input)
+	})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating S3 object ACL for %s/%s: %w", bucket, key, err))
+	}
+
+	d.SetId(ObjectACLCreateResourceID(bucket, expectedBucketOwner, key, versionID, acl))
+
+	return resourceObjectAclRead(ctx, d, meta)
+}
+
+func resourceObjectAclRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket, expectedBucketOwner, key, versionID, acl, err := ObjectACLParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &s3.GetObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	output, err := conn.GetObjectAclWithContext(ctx, input)
+
+	if !d.IsNewResource() && (tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) || tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchKey)) {
+		log.Printf("[WARN] S3 Object ACL (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error getting S3 object ACL (%s): %w", d.Id(), err))
+	}
+
+	if output == nil {
+		return diag.FromErr(fmt.Errorf("error getting S3 object ACL (%s): empty output", d.Id()))
+	}
+
+	d.Set("acl", acl)
+	d.Set("bucket", bucket)
+	d.Set("key", key)
+	d.Set("version_id", versionID)
+	d.Set("expected_bucket_owner", expectedBucketOwner)
+	if err := d.Set("access_control_policy", flattenBucketAclAccessControlPolicy(&s3.GetBucketAclOutput{
+		Grants: output.Grants,
+		Owner:  output.Owner,
+	})); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting access_control_policy: %w", err))
+	}
+
+	return nil
+}
+
+func resourceObjectAclUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket, expectedBucketOwner, key, versionID, acl, err := ObjectACLParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &s3.PutObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	if d.HasChange("access_control_policy") {
+		input.AccessControlPolicy = expandBucketAclAccessControlPolicy(d.Get("access_control_policy").([]interface{}))
+	}
+
+	if d.HasChange("acl") {
+		acl = d.Get("acl").(string)
+		input.ACL = aws.String(acl)
+	}
+
+	_, err = conn.PutObjectAclWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating S3 object ACL (%s): %w", d.Id(), err))
+	}
+
+	if d.HasChange("acl") {
+		// Set new ACL value back in resource ID
+		d.SetId(ObjectACLCreateResourceID(bucket, expectedBucketOwner, key, versionID, acl))
+	}
+
+	return resourceObjectAclRead(ctx, d, meta)
+}
+
+func resourceObjectAclDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("revert_to_private_on_destroy").(bool) {
+		log.Printf("[DEBUG] Skipping reverting S3 Object ACL (%s), revert_to_private_on_destroy is false", d.Id())
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).S3Conn
+
+	bucket, expectedBucketOwner, key, versionID, _, err := ObjectACLParseResourceID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &s3.PutObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+	}
+
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	if expectedBucketOwner != "" {
+		input.ExpectedBucketOwner = aws.String(expectedBucketOwner)
+	}
+
+	log.Printf("[DEBUG] Reverting S3 Object ACL (%s) to private on destroy", d.Id())
+	_, err = conn.PutObjectAclWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) || tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchKey) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reverting S3 object ACL (%s) to private on destroy: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceObjectAclCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return validateBucketAclAccessControlPolicyGrants(d)
+}
+
+// ObjectACLCreateResourceID is a method for creating an ID string
+// encoding the bucket name, optional accountID, object key, and optional
+// versionID/ACL: BUCKET[,EXPECTED_BUCKET_OWNER]/KEY[?versionId=VERSION_ID][&acl=ACL].
+//
+// The key is query-escaped so that characters with meaning elsewhere in the
+// ID ("/", "?", "%", "+", all valid in S3 object keys) can't be confused
+// with the ID's own delimiters; ObjectACLParseResourceID reverses this with
+// url.QueryUnescape. versionID and acl are carried in the query string,
+// rather than as additional "/"-delimited segments, because object keys
+// routinely contain "/" themselves (e.g. "images/logo.png") and a trailing
+// segment would be indistinguishable from the tail of such a key.
+func ObjectACLCreateResourceID(bucket, expectedBucketOwner, key, versionID, acl string) string {
+	bucketPart := bucket
+	if expectedBucketOwner != "" {
+		bucketPart = strings.Join([]string{bucket, expectedBucketOwner}, BucketAndExpectedBucketOwnerSeparator)
+	}
+
+	var query []string
+	if versionID != "" {
+		query = append(query, fmt.Sprintf("versionId=%s", versionID))
+	}
+	if acl != "" {
+		query = append(query, fmt.Sprintf("acl=%s", acl))
+	}
+
+	keyPart := url.QueryEscape(key)
+	if len(query) > 0 {
+		keyPart = fmt.Sprintf("%s?%s", keyPart, strings.Join(query, "&"))
+	}
+
+	return strings.Join([]string{bucketPart, keyPart}, ObjectAclKeySeparator)
+}
+
+// ObjectACLParseResourceID is a method for parsing the ID string
+// back into the bucket name, accountID, object key, versionID, and ACL.
+func ObjectACLParseResourceID(id string) (bucket, expectedBucketOwner, key, versionID, acl string, err error) {
+	parts := strings.SplitN(id, ObjectAclKeySeparator, 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		err = fmt.Errorf("unexpected format for ID (%s), expected BUCKET[%[2]sEXPECTED_BUCKET_OWNER]%[3]sKEY[?versionId=VERSION_ID][&acl=ACL]", id, BucketAndExpectedBucketOwnerSeparator, ObjectAclKeySeparator)
+		return
+	}
+
+	bucketParts := strings.Split(parts[0], BucketAndExpectedBucketOwnerSeparator)
+	bucket = bucketParts[0]
+	if len(bucketParts) == 2 && bucketParts[1] != "" {
+		expectedBucketOwner = bucketParts[1]
+	}
+
+	rest := parts[1]
+
+	if idx := strings.Index(rest, "?"); idx != -1 {
+		key = rest[:idx]
+		for _, param := range strings.Split(rest[idx+1:], "&") {
+			switch {
+			case strings.HasPrefix(param, "versionId="):
+				versionID = strings.TrimPrefix(param, "versionId=")
+			case strings.HasPrefix(param, "acl="):
+				acl = strings.TrimPrefix(param, "acl=")
+			}
+		}
+	} else {
+		key = rest
+	}
+
+	key, err = url.QueryUnescape(key)
+	if err != nil {
+		err = fmt.Errorf("unexpected format for ID (%s): %w", id, err)
+		return
+	}
+
+	if key == "" {
+		err = fmt.Errorf("unexpected format for ID (%s), key cannot be empty", id)
+	}
+
+	return
+}
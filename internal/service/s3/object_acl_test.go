@@ -0,0 +1,133 @@
+package s3
+
+import (
+	"testing"
+)
+
+func TestObjectACLCreateParseResourceID(t *testing.T) {
+	testCases := []struct {
+		name                string
+		bucket              string
+		expectedBucketOwner string
+		key                 string
+		versionID           string
+		acl                 string
+	}{
+		{
+			name:   "key only",
+			bucket: "mybucket",
+			key:    "object.txt",
+		},
+		{
+			name:   "key with slashes",
+			bucket: "mybucket",
+			key:    "images/logo.png",
+		},
+		{
+			name:                "key with slashes and expected bucket owner",
+			bucket:              "mybucket",
+			expectedBucketOwner: "123456789012",
+			key:                 "images/logo.png",
+		},
+		{
+			name:      "key with slashes and version",
+			bucket:    "mybucket",
+			key:       "images/logo.png",
+			versionID: "abc123",
+		},
+		{
+			name:   "key with slashes and acl",
+			bucket: "mybucket",
+			key:    "images/logo.png",
+			acl:    "private",
+		},
+		{
+			name:      "key with slashes, version, and acl",
+			bucket:    "mybucket",
+			key:       "images/nested/logo.png",
+			versionID: "abc123",
+			acl:       "public-read",
+		},
+		{
+			name:                "key with slashes, expected bucket owner, version, and acl",
+			bucket:              "mybucket",
+			expectedBucketOwner: "123456789012",
+			key:                 "images/nested/logo.png",
+			versionID:           "abc123",
+			acl:                 "public-read",
+		},
+		{
+			name:   "key with a plus sign",
+			bucket: "mybucket",
+			key:    "foo+bar.txt",
+		},
+		{
+			name:   "key with a percent sign",
+			bucket: "mybucket",
+			key:    "100%done.txt",
+		},
+		{
+			name:   "key with a question mark and acl",
+			bucket: "mybucket",
+			key:    "weird?file.txt",
+			acl:    "private",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			id := ObjectACLCreateResourceID(testCase.bucket, testCase.expectedBucketOwner, testCase.key, testCase.versionID, testCase.acl)
+
+			bucket, expectedBucketOwner, key, versionID, acl, err := ObjectACLParseResourceID(id)
+			if err != nil {
+				t.Fatalf("unexpected error parsing ID (%s): %s", id, err)
+			}
+
+			if bucket != testCase.bucket {
+				t.Errorf("got bucket %q, want %q", bucket, testCase.bucket)
+			}
+			if expectedBucketOwner != testCase.expectedBucketOwner {
+				t.Errorf("got expectedBucketOwner %q, want %q", expectedBucketOwner, testCase.expectedBucketOwner)
+			}
+			if key != testCase.key {
+				t.Errorf("got key %q, want %q", key, testCase.key)
+			}
+			if versionID != testCase.versionID {
+				t.Errorf("got versionID %q, want %q", versionID, testCase.versionID)
+			}
+			if acl != testCase.acl {
+				t.Errorf("got acl %q, want %q", acl, testCase.acl)
+			}
+		})
+	}
+}
+
+func TestObjectACLParseResourceID_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		id   string
+	}{
+		{
+			name: "empty",
+			id:   "",
+		},
+		{
+			name: "no key",
+			id:   "mybucket/",
+		},
+		{
+			name: "no bucket",
+			id:   "/key",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, _, _, _, _, err := ObjectACLParseResourceID(testCase.id); err == nil {
+				t.Errorf("expected error parsing ID (%s), got none", testCase.id)
+			}
+		})
+	}
+}